@@ -0,0 +1,327 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+//go:generate buf generate
+
+package internal
+
+import (
+	"context"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/andrew-morris/bashhub-server/pkg/pb"
+	jwt "github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// GrpcAddr is the listen address for the gRPC server. Empty disables it.
+	GrpcAddr string
+)
+
+// grpcRun starts the gRPC server alongside the Gin HTTP server, sharing the
+// same Service layer and DB connection. It blocks, so callers run it in its
+// own goroutine.
+func grpcRun(svc *Service) {
+	if GrpcAddr == "" {
+		return
+	}
+
+	lis, err := net.Listen("tcp", GrpcAddr)
+	if err != nil {
+		log.Fatal("gRPC listen error:" + err.Error())
+	}
+
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcAuthUnaryInterceptor),
+		grpc.StreamInterceptor(grpcAuthStreamInterceptor),
+	)
+	pb.RegisterAuthServiceServer(srv, &authServer{svc: svc})
+	pb.RegisterCommandServiceServer(srv, &commandServer{svc: svc})
+	pb.RegisterSystemServiceServer(srv, &systemServer{svc: svc})
+	pb.RegisterStatusServiceServer(srv, &statusServer{svc: svc})
+
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal("gRPC serve error:" + err.Error())
+	}
+}
+
+// grpcClaimsKey is the context key the auth interceptors stash the
+// authenticated username under, mirroring jwt.ExtractClaims for HTTP.
+type grpcClaimsKeyType struct{}
+
+var grpcClaimsKey grpcClaimsKeyType
+
+// grpcAuthenticate reads the JWT from the "authorization" metadata, the same
+// place grpc-gateway and most grpc clients put bearer tokens, and resolves
+// it to a username the same way authMiddleware does for HTTP.
+func grpcAuthenticate(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ctx, status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	token := strings.TrimPrefix(values[0], "Bearer ")
+	username, err := parseJwtUsername(token)
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return context.WithValue(ctx, grpcClaimsKey, username), nil
+}
+
+func grpcUsername(ctx context.Context) string {
+	username, _ := ctx.Value(grpcClaimsKey).(string)
+	return username
+}
+
+// parseJwtUsername verifies a login JWT the same way gin-jwt's
+// MiddlewareFunc does for HTTP, rejects it if its "tokenVersion" claim is
+// stale (mirroring the Gin Authorizator's check so a password reset
+// invalidates gRPC sessions too), and returns the "username" claim.
+func parseJwtUsername(tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		return []byte(config.getSecret()), nil
+	}, jwt.WithValidMethods([]string{"HS256"}))
+	if err != nil || !token.Valid {
+		return "", status.Error(codes.Unauthenticated, "invalid token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "invalid claims")
+	}
+	username, ok := claims["username"].(string)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing username claim")
+	}
+	tokenVersion, _ := claims["tokenVersion"].(float64)
+	if int(tokenVersion) != userGetTokenVersion(username) {
+		return "", status.Error(codes.Unauthenticated, "token has been invalidated")
+	}
+	return username, nil
+}
+
+// grpcTokenGenerator mints the same style of JWT LoginHandler issues over
+// HTTP, so a single bashhub CLI token works against either transport.
+func grpcTokenGenerator(username, systemName string) (string, int64, error) {
+	expire := time.Now().Add(10000 * time.Hour).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"username":     username,
+		"systemName":   systemName,
+		"tokenVersion": userGetTokenVersion(username),
+		"exp":          expire,
+	})
+	signed, err := token.SignedString([]byte(config.getSecret()))
+	return signed, expire, err
+}
+
+// grpcUnauthenticatedMethods lists the only RPC that runs before a caller
+// has a token: logging in. Refresh is NOT exempt — it calls grpcUsername and
+// genuinely requires (and re-checks the tokenVersion of) an existing JWT, so
+// do not add it here or token-version invalidation stops applying to it.
+var grpcUnauthenticatedMethods = map[string]bool{
+	"/bashhub.v1.AuthService/Login": true,
+}
+
+func grpcAuthUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if grpcUnauthenticatedMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	authedCtx, err := grpcAuthenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(authedCtx, req)
+}
+
+type grpcAuthedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *grpcAuthedStream) Context() context.Context {
+	return s.ctx
+}
+
+func grpcAuthStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	authedCtx, err := grpcAuthenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &grpcAuthedStream{ServerStream: ss, ctx: authedCtx})
+}
+
+type authServer struct {
+	pb.UnimplementedAuthServiceServer
+	svc *Service
+}
+
+func (a *authServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	if DisablePasswordLogin {
+		return nil, status.Error(codes.Unimplemented, "password login is disabled")
+	}
+	user := User{Username: req.Username, Password: req.Password}
+	if !user.userExists() {
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+	token, expire, err := grpcTokenGenerator(user.Username, user.userGetSystemName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.LoginResponse{AccessToken: token, Expire: expire}, nil
+}
+
+func (a *authServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.LoginResponse, error) {
+	username := grpcUsername(ctx)
+	token, expire, err := grpcTokenGenerator(username, "")
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.LoginResponse{AccessToken: token, Expire: expire}, nil
+}
+
+type commandServer struct {
+	pb.UnimplementedCommandServiceServer
+	svc *Service
+}
+
+func (c *commandServer) Insert(ctx context.Context, req *pb.InsertRequest) (*pb.InsertResponse, error) {
+	command := Command{
+		Uuid:       req.Command.Uuid,
+		Command:    req.Command.Command,
+		Created:    req.Command.Created,
+		Path:       req.Command.Path,
+		ExitStatus: int(req.Command.ExitStatus),
+		SystemName: req.Command.SystemName,
+		SessionID:  req.Command.SessionId,
+	}
+	command.User.ID = userGetId(grpcUsername(ctx))
+	c.svc.CommandInsert(command)
+	commandInsertTotal.Inc()
+	return &pb.InsertResponse{Uuid: command.Uuid}, nil
+}
+
+func (c *commandServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.CommandList, error) {
+	var command Command
+	command.User.ID = userGetId(grpcUsername(ctx))
+	command.Uuid = req.Uuid
+	result := c.svc.CommandGetUUID(command)
+	return &pb.CommandList{Commands: []*pb.Command{toPbCommand(result)}}, nil
+}
+
+// Search sends matching commands to the client one message at a time instead
+// of as a single IndentedJSON body like the HTTP handler. The result set is
+// still loaded in full by CommandGet before the first message is sent.
+func (c *commandServer) Search(req *pb.SearchRequest, stream pb.CommandService_SearchServer) error {
+	var command Command
+	command.User.ID = userGetId(grpcUsername(stream.Context()))
+	command.Query = req.Query
+	command.Path = req.Path
+	command.SystemName = req.SystemName
+	command.Unique = req.Unique
+	command.Limit = int(req.Limit)
+	if command.Limit == 0 {
+		command.Limit = 100
+	}
+
+	for _, result := range c.svc.CommandGet(command) {
+		if err := stream.Send(toPbCommand(result)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *commandServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	var command Command
+	command.User.ID = userGetId(grpcUsername(ctx))
+	command.Uuid = req.Uuid
+	c.svc.CommandDelete(command)
+	return &pb.DeleteResponse{}, nil
+}
+
+func toPbCommand(c Command) *pb.Command {
+	return &pb.Command{
+		Uuid:       c.Uuid,
+		Command:    c.Command,
+		Created:    c.Created,
+		Path:       c.Path,
+		ExitStatus: int32(c.ExitStatus),
+		SystemName: c.SystemName,
+		SessionId:  c.SessionID,
+	}
+}
+
+type systemServer struct {
+	pb.UnimplementedSystemServiceServer
+	svc *Service
+}
+
+func (s *systemServer) Insert(ctx context.Context, req *pb.System) (*pb.InsertResponse, error) {
+	system := System{}
+	system.Mac = req.Mac
+	system.User.ID = userGetId(grpcUsername(ctx))
+	s.svc.SystemInsert(system)
+	return &pb.InsertResponse{}, nil
+}
+
+func (s *systemServer) Get(ctx context.Context, req *pb.SystemGetRequest) (*pb.System, error) {
+	var system System
+	system.User.ID = userGetId(grpcUsername(ctx))
+	result := s.svc.SystemGet(system)
+	return &pb.System{Mac: result.Mac}, nil
+}
+
+type statusServer struct {
+	pb.UnimplementedStatusServiceServer
+	svc *Service
+}
+
+func (s *statusServer) Get(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	username := grpcUsername(ctx)
+	var st Status
+	st.Username = username
+	st.User.ID = userGetId(username)
+	st.SessionStartTime = req.StartTime
+	st.ProcessID = int(req.ProcessId)
+
+	result, err := s.svc.StatusGet(st)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.StatusResponse{
+		Username:             result.Username,
+		TotalCommands:        int32(result.TotalCommands),
+		TotalSessions:        int32(result.TotalSessions),
+		TotalSystems:         int32(result.TotalSystems),
+		TotalCommandsToday:   int32(result.TotalCommandsToday),
+		SessionStartTime:     result.SessionStartTime,
+		SessionTotalCommands: int32(result.SessionTotalCommands),
+	}, nil
+}
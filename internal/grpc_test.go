@@ -0,0 +1,54 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import (
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// TestParseJwtUsernameRejectsAlgConfusion verifies the gRPC JWT verifier is
+// pinned to HS256 and refuses a token that declares a different algorithm,
+// even one signed with the same secret. This is checked before the
+// tokenVersion lookup, so it needs no DB connection to exercise.
+func TestParseJwtUsernameRejectsAlgConfusion(t *testing.T) {
+	secret := []byte(config.getSecret())
+
+	claims := jwt.MapClaims{
+		"username":     "alice",
+		"tokenVersion": 0,
+	}
+
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("signing none-alg token: %v", err)
+	}
+	if _, err := parseJwtUsername(none); err == nil {
+		t.Fatal("parseJwtUsername accepted an alg=none token")
+	}
+
+	hs512, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString(secret)
+	if err != nil {
+		t.Fatalf("signing HS512 token: %v", err)
+	}
+	if _, err := parseJwtUsername(hs512); err == nil {
+		t.Fatal("parseJwtUsername accepted an HS512 token")
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// LogFormat selects the access log encoding: "text" (default) or "json".
+	LogFormat string
+	// LogLevel filters which log lines are emitted: "debug", "info", "warn"
+	// or "error" (default "info"). Access log lines are leveled by response
+	// status: 2xx/3xx are "info", 4xx are "warn", 5xx are "error"; a line is
+	// only written if its level is at or above LogLevel.
+	LogLevel string
+)
+
+// logLevelSeverity orders the supported levels so they can be compared;
+// an unrecognized LogLevel is treated as "info".
+var logLevelSeverity = map[string]int{
+	"debug": 0,
+	"info":  1,
+	"warn":  2,
+	"error": 3,
+}
+
+// accessLogSeverity classifies an access log line's level from its response
+// status code.
+func accessLogSeverity(status int) string {
+	switch {
+	case status >= 500:
+		return "error"
+	case status >= 400:
+		return "warn"
+	default:
+		return "info"
+	}
+}
+
+// logLevelAllows reports whether a line at level should be emitted given the
+// configured LogLevel.
+func logLevelAllows(level string) bool {
+	configured, ok := logLevelSeverity[LogLevel]
+	if !ok {
+		configured = logLevelSeverity["info"]
+	}
+	return logLevelSeverity[level] >= configured
+}
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bashhub_requests_total",
+		Help: "Total HTTP requests by route and status code.",
+	}, []string{"route", "method", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bashhub_request_duration_seconds",
+		Help:    "HTTP request latency by route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	commandInsertTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bashhub_command_insert_total",
+		Help: "Total commands ingested via POST /api/v1/command or gRPC CommandService.Insert.",
+	})
+
+	activeSessionsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "bashhub_active_sessions",
+		Help: "Shell sessions opened minus sessions closed via the HTTP API since this server started.",
+	})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bashhub_db_query_duration_seconds",
+		Help:    "Database query latency by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+)
+
+// requestIDMiddleware assigns an X-Request-ID to every request, reusing the
+// client's value if one was sent, and stores it in the gin context so
+// handlers and the access logger can both reference it.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader("X-Request-ID")
+		if id == "" {
+			id = uuid.New().String()
+		}
+		c.Set("request_id", id)
+		c.Writer.Header().Set("X-Request-ID", id)
+		c.Next()
+	}
+}
+
+// metricsMiddleware records request counts and latency histograms for the
+// Prometheus /metrics endpoint.
+func metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		requestsTotal.WithLabelValues(route, c.Request.Method, fmt.Sprint(c.Writer.Status())).Inc()
+		requestDuration.WithLabelValues(route, c.Request.Method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// accessLogEntry is the shape of a single JSON access log line.
+type accessLogEntry struct {
+	Timestamp string  `json:"timestamp"`
+	RequestID string  `json:"request_id"`
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Status    int     `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	ClientIP  string  `json:"client_ip"`
+	UserAgent string  `json:"user_agent"`
+	Username  string  `json:"username,omitempty"`
+}
+
+// jsonLogFormatter renders a gin.LogFormatterParams as a single JSON line,
+// used when --log-format=json.
+func jsonLogFormatter(param gin.LogFormatterParams) string {
+	if !logLevelAllows(accessLogSeverity(param.StatusCode)) {
+		return ""
+	}
+
+	entry := accessLogEntry{
+		Timestamp: param.TimeStamp.Format(time.RFC3339),
+		Method:    param.Method,
+		Path:      param.Path,
+		Status:    param.StatusCode,
+		LatencyMs: float64(param.Latency.Microseconds()) / 1000,
+		ClientIP:  param.ClientIP,
+		UserAgent: param.Request.UserAgent(),
+	}
+	if id, ok := param.Keys["request_id"].(string); ok {
+		entry.RequestID = id
+	}
+	if claims, ok := param.Keys["JWT_PAYLOAD"].(jwt.MapClaims); ok {
+		if username, ok := claims["username"].(string); ok {
+			entry.Username = username
+		}
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Sprintf(`{"error":"failed to marshal access log: %s"}`+"\n", err)
+	}
+	return string(b) + "\n"
+}
+
+// textLogFormatter is the original printf-based access log format, kept as
+// the default so existing log scraping keeps working.
+func textLogFormatter(param gin.LogFormatterParams) string {
+	if !logLevelAllows(accessLogSeverity(param.StatusCode)) {
+		return ""
+	}
+
+	return fmt.Sprintf("[BASHHUB-SERVER] %v | %3d | %13v | %15s | %-7s  %s\n",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Path,
+	)
+}
+
+// accessLogFormatter picks the text or JSON formatter based on LogFormat.
+func accessLogFormatter() gin.LogFormatter {
+	if LogFormat == "json" {
+		return jsonLogFormatter
+	}
+	return textLogFormatter
+}
+
+// metricsHandler serves Prometheus exposition format at /metrics.
+func metricsHandler() gin.HandlerFunc {
+	h := promhttp.Handler()
+	return func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/appleboy/gin-jwt/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+)
+
+var (
+	// OidcIssuer is the identity provider's issuer URL, e.g.
+	// https://accounts.google.com.
+	OidcIssuer string
+	// OidcClientID is the OAuth2 client id registered with the provider.
+	OidcClientID string
+	// OidcClientSecret is the OAuth2 client secret registered with the provider.
+	OidcClientSecret string
+	// OidcRedirectURL is the callback URL registered with the provider,
+	// normally "<Addr>/api/v1/oauth/callback".
+	OidcRedirectURL string
+	// DisablePasswordLogin, when true, removes the POST /api/v1/login and
+	// POST /api/v1/user routes so accounts can only be created and signed
+	// into via SSO.
+	DisablePasswordLogin bool
+
+	oidcProvider *oidc.Provider
+	oauthConfig  *oauth2.Config
+	oidcVerifier *oidc.IDTokenVerifier
+
+	oauthStateMu sync.Mutex
+	oauthState   = map[string]time.Time{}
+)
+
+// userFindOrCreateOAuth looks up the User owned by a given provider/subject
+// pair, creating one (keyed by email for the username) on first login. It
+// refuses to attach an IdP identity to an email that already has an account
+// under a *different* provider/subject (or no OAuth link at all) — without
+// this, a caller controlling an IdP-issued email equal to an existing
+// password user's Username could log straight into that account.
+func userFindOrCreateOAuth(provider, subject, email string) (User, error) {
+	var user User
+	db.Where("o_auth_provider = ? AND o_auth_subject = ?", provider, subject).First(&user)
+	if user.ID != 0 {
+		return user, nil
+	}
+
+	if existing := userGetByEmail(email); existing.ID != 0 {
+		return User{}, fmt.Errorf("an account already exists for %s and is not linked to this identity provider", email)
+	}
+
+	user = User{
+		Username:      email,
+		Email:         email,
+		OAuthProvider: provider,
+		OAuthSubject:  subject,
+	}
+	if err := db.Create(&user).Error; err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// oidcEnabled reports whether SSO login has been configured via the
+// --oidc-issuer flag.
+func oidcEnabled() bool {
+	return OidcIssuer != ""
+}
+
+// oidcInit sets up the OIDC provider and oauth2 config. It is called from
+// Run() when OidcIssuer is set.
+func oidcInit() error {
+	provider, err := oidc.NewProvider(context.Background(), OidcIssuer)
+	if err != nil {
+		return err
+	}
+	oidcProvider = provider
+	oauthConfig = &oauth2.Config{
+		ClientID:     OidcClientID,
+		ClientSecret: OidcClientSecret,
+		RedirectURL:  OidcRedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+	oidcVerifier = provider.Verifier(&oidc.Config{ClientID: OidcClientID})
+	return nil
+}
+
+// newOauthState generates a random state token, records it with an
+// expiration so oauthCallback can validate it came from us, and returns it.
+// Abandoned logins are never explicitly deleted, so each call also sweeps
+// any already-expired entries to keep the map from growing unboundedly.
+func newOauthState() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	state := hex.EncodeToString(b)
+
+	oauthStateMu.Lock()
+	now := time.Now()
+	for s, expires := range oauthState {
+		if now.After(expires) {
+			delete(oauthState, s)
+		}
+	}
+	oauthState[state] = now.Add(10 * time.Minute)
+	oauthStateMu.Unlock()
+
+	return state
+}
+
+// consumeOauthState validates and removes a state token, returning false if
+// it is unknown or expired.
+func consumeOauthState(state string) bool {
+	oauthStateMu.Lock()
+	defer oauthStateMu.Unlock()
+
+	expires, ok := oauthState[state]
+	delete(oauthState, state)
+	if !ok || time.Now().After(expires) {
+		return false
+	}
+	return true
+}
+
+// oauthLogin redirects the browser to the provider's authorize endpoint.
+func oauthLogin(c *gin.Context) {
+	state := newOauthState()
+	c.Redirect(http.StatusFound, oauthConfig.AuthCodeURL(state))
+}
+
+// oauthCallback exchanges the authorization code for an ID token, resolves
+// or creates the local User it identifies, and mints the same JWT
+// LoginHandler issues so existing bashhub clients keep working unchanged.
+func oauthCallback(authMiddleware *jwt.GinJWTMiddleware) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !consumeOauthState(c.Query("state")) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired oauth state"})
+			return
+		}
+
+		token, err := oauthConfig.Exchange(context.Background(), c.Query("code"))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		rawIDToken, ok := token.Extra("id_token").(string)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "id_token missing from provider response"})
+			return
+		}
+		idToken, err := oidcVerifier.Verify(context.Background(), rawIDToken)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		var claims struct {
+			Subject string `json:"sub"`
+			Email   string `json:"email"`
+		}
+		if err := idToken.Claims(&claims); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := userFindOrCreateOAuth(OidcIssuer, claims.Subject, claims.Email)
+		if err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+
+		userToken, expire, err := authMiddleware.TokenGenerator(&user)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"accessToken": userToken,
+			"expire":      expire.Format(time.RFC3339),
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsumeOauthStateRejectsUnknown(t *testing.T) {
+	if consumeOauthState("never-issued") {
+		t.Fatal("consumeOauthState should reject a state it never issued")
+	}
+}
+
+func TestConsumeOauthStateAcceptsOnce(t *testing.T) {
+	state := newOauthState()
+
+	if !consumeOauthState(state) {
+		t.Fatal("consumeOauthState should accept a freshly issued state")
+	}
+	if consumeOauthState(state) {
+		t.Fatal("consumeOauthState should reject a replayed state")
+	}
+}
+
+func TestConsumeOauthStateRejectsExpired(t *testing.T) {
+	state := "expired-state"
+
+	oauthStateMu.Lock()
+	oauthState[state] = time.Now().Add(-time.Minute)
+	oauthStateMu.Unlock()
+
+	if consumeOauthState(state) {
+		t.Fatal("consumeOauthState should reject an expired state")
+	}
+}
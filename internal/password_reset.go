@@ -0,0 +1,224 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// SmtpHost is the SMTP server used to send password reset emails.
+	SmtpHost string
+	// SmtpUser is the SMTP username.
+	SmtpUser string
+	// SmtpPass is the SMTP password.
+	SmtpPass string
+	// SmtpFrom is the "From" address on password reset emails.
+	SmtpFrom string
+)
+
+// PasswordReset is a single outstanding password reset request. TokenHash is
+// the sha256 of the token emailed to the user; the plaintext is never
+// persisted.
+type PasswordReset struct {
+	ID        uint   `json:"id" gorm:"primary_key"`
+	UserId    uint   `json:"userId"`
+	TokenHash string `json:"-" gorm:"type:varchar(64);unique_index"`
+	ExpiresAt int64  `json:"expiresAt"`
+	UsedAt    int64  `json:"usedAt"`
+}
+
+const passwordResetTTL = time.Hour
+
+// valid reports whether a reset token is still usable: it must exist, not
+// already have been consumed, and not have expired.
+func (r PasswordReset) valid(now time.Time) bool {
+	return r.ID != 0 && r.UsedAt == 0 && r.ExpiresAt >= now.Unix()
+}
+
+var resetRateLimiter = newRateLimiter(5, time.Hour)
+
+// passwordResetRequest body is {"email": "..."}.
+type passwordResetRequestBody struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// passwordResetConfirmBody is {"token": "...", "password": "..."}.
+type passwordResetConfirmBody struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// passwordResetRequestHandler emails a reset token if the address belongs to
+// a user, but always returns 200 so the response can't be used to enumerate
+// registered emails.
+func passwordResetRequestHandler(c *gin.Context) {
+	var body passwordResetRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !resetRateLimiter.allow("email:"+body.Email) || !resetRateLimiter.allow("ip:"+c.ClientIP()) {
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	}
+
+	user := userGetByEmail(body.Email)
+	if user.ID != 0 {
+		plaintext, hash := generateResetToken()
+		reset := PasswordReset{
+			UserId:    user.ID,
+			TokenHash: hash,
+			ExpiresAt: time.Now().Add(passwordResetTTL).Unix(),
+		}
+		db.Create(&reset)
+
+		if err := sendPasswordResetEmail(user.Email, plaintext); err != nil {
+			fmt.Println("password reset email error:", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// passwordResetConfirmHandler verifies a reset token, updates the user's
+// password, consumes the token, and bumps the user's token version so every
+// outstanding login JWT is invalidated.
+func passwordResetConfirmHandler(c *gin.Context) {
+	var body passwordResetConfirmBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var reset PasswordReset
+	db.Where("token_hash = ?", hashResetToken(body.Token)).First(&reset)
+	if !reset.valid(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired token"})
+		return
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	db.Model(&User{}).Where("id = ?", reset.UserId).Update("password", string(hashed))
+	db.Model(&PasswordReset{}).Where("id = ?", reset.ID).Update("used_at", time.Now().Unix())
+	userBumpTokenVersion(reset.UserId)
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// userGetByEmail looks up a user by email, returning a zero-value User if
+// none matches.
+func userGetByEmail(email string) User {
+	var user User
+	db.Where("email = ?", email).First(&user)
+	return user
+}
+
+// userGetTokenVersion returns a user's current TokenVersion, checked by
+// Authorizator against the version baked into each JWT's claims.
+func userGetTokenVersion(username string) int {
+	var user User
+	db.Where("username = ?", username).First(&user)
+	return user.TokenVersion
+}
+
+// userBumpTokenVersion increments a user's TokenVersion so Authorizator
+// rejects every JWT minted before the bump, effectively invalidating all of
+// that user's outstanding sessions.
+func userBumpTokenVersion(userId uint) {
+	db.Model(&User{}).Where("id = ?", userId).UpdateColumn("token_version", gorm.Expr("token_version + 1"))
+}
+
+// generateResetToken returns a random plaintext token and its stored hash.
+func generateResetToken() (plaintext, hash string) {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	plaintext = hex.EncodeToString(b)
+	return plaintext, hashResetToken(plaintext)
+}
+
+func hashResetToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// sendPasswordResetEmail sends the reset token via the configured SMTP
+// relay. Swappable for a different transport by replacing this function
+// alone; callers only depend on the (to, token) signature.
+func sendPasswordResetEmail(to, token string) error {
+	if SmtpHost == "" {
+		return fmt.Errorf("smtp not configured")
+	}
+	auth := smtp.PlainAuth("", SmtpUser, SmtpPass, SmtpHost)
+	msg := []byte(fmt.Sprintf("To: %s\r\nSubject: bashhub-server password reset\r\n\r\n"+
+		"Your password reset token is: %s\r\nIt expires in one hour.\r\n", to, token))
+	return smtp.SendMail(SmtpHost+":587", auth, SmtpFrom, []string{to}, msg)
+}
+
+// rateLimiter is a simple sliding-window limiter keyed by an arbitrary string
+// (an email address or client IP): allow admits at most limit calls per key
+// within any trailing window. It is good enough to blunt password-reset
+// abuse without pulling in an external dependency.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{limit: limit, window: window, hits: map[string][]time.Time{}}
+}
+
+func (r *rateLimiter) allow(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-r.window)
+	kept := r.hits[key][:0]
+	for _, t := range r.hits[key] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) >= r.limit {
+		r.hits[key] = kept
+		return false
+	}
+	r.hits[key] = append(kept, now)
+	return true
+}
@@ -0,0 +1,63 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPasswordResetValid(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		name  string
+		reset PasswordReset
+		want  bool
+	}{
+		{
+			name:  "unknown token",
+			reset: PasswordReset{},
+			want:  false,
+		},
+		{
+			name:  "already used",
+			reset: PasswordReset{ID: 1, UsedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix()},
+			want:  false,
+		},
+		{
+			name:  "expired",
+			reset: PasswordReset{ID: 1, ExpiresAt: now.Add(-time.Minute).Unix()},
+			want:  false,
+		},
+		{
+			name:  "unused and unexpired",
+			reset: PasswordReset{ID: 1, ExpiresAt: now.Add(time.Hour).Unix()},
+			want:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.reset.valid(now); got != tc.want {
+				t.Errorf("valid() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -39,6 +39,9 @@ type User struct {
 	Mac              *string `json:"mac" gorm:"-"`
 	RegistrationCode *string `json:"registrationCode"`
 	SystemName       string  `json:"systemName" gorm:"-"`
+	OAuthProvider    string  `json:"-" gorm:"type:varchar(200)"`
+	OAuthSubject     string  `json:"-" gorm:"type:varchar(200)"`
+	TokenVersion     int     `json:"-" gorm:"default:0"`
 }
 
 type Query struct {
@@ -49,8 +52,7 @@ type Query struct {
 	ExitStatus int    `json:"exitStatus"`
 	Username   string `json:"username"`
 	SystemName string `gorm:"-"  json:"systemName"`
-	//TODO: implement sessions
-	SessionID string `json:"session_id"`
+	SessionID  string `json:"session_id"`
 }
 
 type Command struct {
@@ -62,6 +64,7 @@ type Command struct {
 	Path             string `json:"path"`
 	SystemName       string `json:"systemName"`
 	ExitStatus       int    `json:"exitStatus"`
+	SessionID        string `json:"session_id"`
 	User             User   `gorm:"association_foreignkey:ID"`
 	UserId           uint
 	Limit            int    `gorm:"-"`
@@ -94,6 +97,15 @@ type Status struct {
 	SessionTotalCommands int    `json:"sessionTotalCommands"`
 }
 
+// statusSessionTotals fills in the session-derived fields of Status from the
+// Session table rather than approximating them from the current process id.
+func (s *Status) statusSessionTotals() {
+	total, start, count := sessionTotals(s.User.ID, s.ProcessID)
+	s.TotalSessions = total
+	s.SessionStartTime = start
+	s.SessionTotalCommands = count
+}
+
 type Config struct {
 	Secret  string
 	ID      int
@@ -133,20 +145,17 @@ func Run() {
 	// Initialize backend
 	dbInit()
 
+	svc := NewService()
+
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
 
-	r.Use(loggerWithFormatterWriter(func(param gin.LogFormatterParams) string {
-		return fmt.Sprintf("[BASHHUB-SERVER] %v | %3d | %13v | %15s | %-7s  %s\n",
-			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
-			param.StatusCode,
-			param.Latency,
-			param.ClientIP,
-			param.Method,
-			param.Path,
-		)
-	}))
+	r.Use(requestIDMiddleware())
+	r.Use(metricsMiddleware())
+	r.Use(loggerWithFormatterWriter(accessLogFormatter()))
+
+	r.GET("/metrics", metricsHandler())
 
 	// the jwt middleware
 	authMiddleware, err := jwt.New(&jwt.GinJWTMiddleware{
@@ -163,17 +172,20 @@ func Run() {
 		PayloadFunc: func(data interface{}) jwt.MapClaims {
 			if v, ok := data.(*User); ok {
 				return jwt.MapClaims{
-					"username":   v.Username,
-					"systemName": v.SystemName,
+					"username":     v.Username,
+					"systemName":   v.SystemName,
+					"tokenVersion": v.TokenVersion,
 				}
 			}
 			return jwt.MapClaims{}
 		},
 		IdentityHandler: func(c *gin.Context) interface{} {
 			claims := jwt.ExtractClaims(c)
+			tokenVersion, _ := claims["tokenVersion"].(float64)
 			return &User{
-				Username:   claims["username"].(string),
-				SystemName: claims["systemName"].(string),
+				Username:     claims["username"].(string),
+				SystemName:   claims["systemName"].(string),
+				TokenVersion: int(tokenVersion),
 			}
 		},
 		Authenticator: func(c *gin.Context) (interface{}, error) {
@@ -184,8 +196,9 @@ func Run() {
 			}
 			if user.userExists() {
 				return &User{
-					Username:   user.Username,
-					SystemName: user.userGetSystemName(),
+					Username:     user.Username,
+					SystemName:   user.userGetSystemName(),
+					TokenVersion: userGetTokenVersion(user.Username),
 				}, nil
 			}
 			fmt.Println("failed")
@@ -194,7 +207,7 @@ func Run() {
 		},
 		Authorizator: func(data interface{}, c *gin.Context) bool {
 			if v, ok := data.(*User); ok && v.usernameExists() {
-				return true
+				return v.TokenVersion == userGetTokenVersion(v.Username)
 			}
 			return false
 		},
@@ -219,33 +232,88 @@ func Run() {
 		})
 	})
 
-	r.POST("/api/v1/login", authMiddleware.LoginHandler)
+	if !DisablePasswordLogin {
+		r.POST("/api/v1/login", authMiddleware.LoginHandler)
 
-	r.POST("/api/v1/user", func(c *gin.Context) {
-		var user User
-		if err := c.ShouldBindJSON(&user); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-		if user.Email == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "email required"})
-			return
+		r.POST("/api/v1/user", func(c *gin.Context) {
+			var user User
+			if err := c.ShouldBindJSON(&user); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if user.Email == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "email required"})
+				return
+			}
+			if user.usernameExists() {
+				c.String(409, "Username already taken")
+				return
+			}
+			if user.emailExists() {
+				c.String(409, "This email address is already registered.")
+				return
+			}
+			user.userCreate()
+
+		})
+	}
+
+	r.POST("/api/v1/user/password-reset/request", passwordResetRequestHandler)
+	r.POST("/api/v1/user/password-reset/confirm", passwordResetConfirmHandler)
+
+	if oidcEnabled() {
+		if err := oidcInit(); err != nil {
+			log.Fatal("OIDC Error:" + err.Error())
 		}
-		if user.usernameExists() {
-			c.String(409, "Username already taken")
+		r.GET("/api/v1/oauth/login", oauthLogin)
+		r.GET("/api/v1/oauth/callback", oauthCallback(authMiddleware))
+	}
+
+	r.Use(patAuthMiddleware(authMiddleware))
+	r.Use(func(c *gin.Context) {
+		if _, ok := c.Get("pat"); ok {
+			c.Next()
 			return
 		}
-		if user.emailExists() {
-			c.String(409, "This email address is already registered.")
+		authMiddleware.MiddlewareFunc()(c)
+	})
+
+	r.POST("/api/v1/user/token", requireScope(ScopeTokenManage), func(c *gin.Context) {
+		var token PersonalAccessToken
+		if err := c.ShouldBindJSON(&token); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		user.userCreate()
+		claims := jwt.ExtractClaims(c)
+		username := claims["username"].(string)
+		token.UserId = userGetId(username)
+		plaintext := token.patCreate()
+		c.JSON(http.StatusOK, gin.H{"token": plaintext})
+	})
 
+	r.GET("/api/v1/user/token", requireScope(ScopeTokenManage), func(c *gin.Context) {
+		var token PersonalAccessToken
+		claims := jwt.ExtractClaims(c)
+		username := claims["username"].(string)
+		token.UserId = userGetId(username)
+		c.IndentedJSON(http.StatusOK, token.patList())
 	})
 
-	r.Use(authMiddleware.MiddlewareFunc())
+	r.DELETE("/api/v1/user/token/:id", requireScope(ScopeTokenManage), func(c *gin.Context) {
+		var token PersonalAccessToken
+		claims := jwt.ExtractClaims(c)
+		username := claims["username"].(string)
+		token.UserId = userGetId(username)
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		token.ID = uint(id)
+		token.patRevoke()
+	})
 
-	r.GET("/api/v1/command/:path", func(c *gin.Context) {
+	r.GET("/api/v1/command/:path", requireScope(ScopeCommandRead), func(c *gin.Context) {
 		var command Command
 		var user User
 		claims := jwt.ExtractClaims(c)
@@ -270,7 +338,7 @@ func Run() {
 			command.Query = c.Query("query")
 			command.SystemName = c.Query("systemName")
 
-			result := command.commandGet()
+			result := svc.CommandGet(command)
 			if len(result) == 0 {
 				c.JSON(http.StatusOK, gin.H{})
 				return
@@ -278,14 +346,14 @@ func Run() {
 			c.IndentedJSON(http.StatusOK, result)
 		} else {
 			command.Uuid = c.Param("path")
-			result := command.commandGetUUID()
+			result := svc.CommandGetUUID(command)
 			result.Username = user.Username
 			c.IndentedJSON(http.StatusOK, result)
 		}
 
 	})
 
-	r.POST("/api/v1/command", func(c *gin.Context) {
+	r.POST("/api/v1/command", requireScope(ScopeCommandWrite), func(c *gin.Context) {
 		var command Command
 		if err := c.ShouldBindJSON(&command); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -298,19 +366,69 @@ func Run() {
 		username := claims["username"].(string)
 		command.User.ID = userGetId(username)
 		command.SystemName = claims["systemName"].(string)
-		command.commandInsert()
+		svc.CommandInsert(command)
+		commandInsertTotal.Inc()
+	})
+
+	r.POST("/api/v1/session", func(c *gin.Context) {
+		var session Session
+		if err := c.ShouldBindJSON(&session); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		claims := jwt.ExtractClaims(c)
+		username := claims["username"].(string)
+		session.UserId = userGetId(username)
+		session.SystemName = claims["systemName"].(string)
+		uuid := session.sessionInsert()
+		activeSessionsGauge.Inc()
+		c.JSON(http.StatusOK, gin.H{"uuid": uuid})
+	})
+
+	r.DELETE("/api/v1/session/:uuid", func(c *gin.Context) {
+		var session Session
+		claims := jwt.ExtractClaims(c)
+		username := claims["username"].(string)
+		session.UserId = userGetId(username)
+		session.Uuid = c.Param("uuid")
+		session.EndTime = time.Now().Unix()
+		session.sessionClose()
+		activeSessionsGauge.Dec()
+	})
+
+	r.GET("/api/v1/session", func(c *gin.Context) {
+		var session Session
+		claims := jwt.ExtractClaims(c)
+		username := claims["username"].(string)
+		session.UserId = userGetId(username)
+		result := session.sessionGetAll()
+		c.IndentedJSON(http.StatusOK, result)
+	})
+
+	r.GET("/api/v1/session/:uuid/command", func(c *gin.Context) {
+		var session Session
+		claims := jwt.ExtractClaims(c)
+		username := claims["username"].(string)
+		session.UserId = userGetId(username)
+		session.Uuid = c.Param("uuid")
+		result := session.sessionCommandGet()
+		if len(result) == 0 {
+			c.JSON(http.StatusOK, gin.H{})
+			return
+		}
+		c.IndentedJSON(http.StatusOK, result)
 	})
 
-	r.DELETE("/api/v1/command/:uuid", func(c *gin.Context) {
+	r.DELETE("/api/v1/command/:uuid", requireScope(ScopeCommandDelete), func(c *gin.Context) {
 		var command Command
 		claims := jwt.ExtractClaims(c)
 		username := claims["username"].(string)
 		command.User.ID = userGetId(username)
 		command.Uuid = c.Param("uuid")
-		command.commandDelete()
+		svc.CommandDelete(command)
 	})
 
-	r.POST("/api/v1/system", func(c *gin.Context) {
+	r.POST("/api/v1/system", requireScope(ScopeSystemWrite), func(c *gin.Context) {
 		var system System
 		err := c.Bind(&system)
 		if err != nil {
@@ -320,7 +438,7 @@ func Run() {
 		username := claims["username"].(string)
 		system.User.ID = userGetId(username)
 
-		system.systemInsert()
+		svc.SystemInsert(system)
 		c.AbortWithStatus(201)
 	})
 
@@ -334,7 +452,7 @@ func Run() {
 		}
 		username := claims["username"].(string)
 		system.User.ID = userGetId(username)
-		result := system.systemGet()
+		result := svc.SystemGet(system)
 		if len(result.Mac) == 0 {
 			c.AbortWithStatus(404)
 			return
@@ -343,7 +461,7 @@ func Run() {
 
 	})
 
-	r.GET("/api/v1/client-view/status", func(c *gin.Context) {
+	r.GET("/api/v1/client-view/status", requireScope(ScopeStatusRead), func(c *gin.Context) {
 		var status Status
 		claims := jwt.ExtractClaims(c)
 		username := claims["username"].(string)
@@ -364,7 +482,7 @@ func Run() {
 		}
 		status.ProcessID = pid
 
-		result, err := status.statusGet()
+		result, err := svc.StatusGet(status)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -374,6 +492,10 @@ func Run() {
 
 	})
 
+	if GrpcAddr != "" {
+		go grpcRun(svc)
+	}
+
 	Addr = strings.ReplaceAll(Addr, "http://", "")
 	err = r.Run(Addr)
 
@@ -0,0 +1,88 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import "time"
+
+// Service wraps the DB-backed operations (commandInsert, commandGet, ...)
+// used by both the Gin HTTP handlers in Run() and the gRPC server in
+// grpcRun(), so both transports run the same DB code. Checks made before an
+// operation stay in each transport's handler and are not shared: the HTTP
+// command route drops commands whose exit status is not 0 or 130, while the
+// gRPC Insert stores every command it receives.
+type Service struct{}
+
+// NewService returns a Service backed by the package's shared DB connection.
+func NewService() *Service {
+	return &Service{}
+}
+
+// observeDBQuery times a DB-backed operation and records it under
+// dbQueryDuration, so every Service method's cost is visible on /metrics
+// regardless of which transport called it.
+func observeDBQuery(operation string, fn func()) {
+	start := time.Now()
+	fn()
+	dbQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+func (s *Service) CommandInsert(command Command) {
+	observeDBQuery("commandInsert", func() { command.commandInsert() })
+}
+
+func (s *Service) CommandGet(command Command) []Command {
+	var result []Command
+	observeDBQuery("commandGet", func() { result = command.commandGet() })
+	return result
+}
+
+func (s *Service) CommandGetUUID(command Command) Command {
+	var result Command
+	observeDBQuery("commandGetUUID", func() { result = command.commandGetUUID() })
+	return result
+}
+
+func (s *Service) CommandDelete(command Command) {
+	observeDBQuery("commandDelete", func() { command.commandDelete() })
+}
+
+func (s *Service) SystemInsert(system System) {
+	observeDBQuery("systemInsert", func() { system.systemInsert() })
+}
+
+func (s *Service) SystemGet(system System) System {
+	var result System
+	observeDBQuery("systemGet", func() { result = system.systemGet() })
+	return result
+}
+
+func (s *Service) StatusGet(status Status) (Status, error) {
+	var result Status
+	var err error
+	observeDBQuery("statusGet", func() {
+		result, err = status.statusGet()
+		if err == nil {
+			result.statusSessionTotals()
+		}
+	})
+	if err != nil {
+		return Status{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import "time"
+
+// Session represents a single shell session, opened when a shell starts and
+// closed when it exits. Commands reference a session via Command.SessionID
+// so a session's full command history can be replayed in order.
+type Session struct {
+	ID         uint   `json:"id" gorm:"primary_key"`
+	Uuid       string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	UserId     uint   `json:"userId"`
+	SystemName string `json:"systemName"`
+	ProcessId  int    `json:"processId"`
+	StartTime  int64  `json:"startTime"`
+	EndTime    int64  `json:"endTime"`
+	Hostname   string `json:"hostname"`
+	Tty        string `json:"tty"`
+}
+
+// sessionInsert creates a new session row and returns its uuid.
+func (s *Session) sessionInsert() string {
+	if s.Uuid == "" {
+		s.Uuid = uuidGen()
+	}
+	if s.StartTime == 0 {
+		s.StartTime = time.Now().Unix()
+	}
+	db.Create(&s)
+	return s.Uuid
+}
+
+// sessionClose sets EndTime on the session matching Uuid for the given user.
+func (s *Session) sessionClose() {
+	db.Model(&Session{}).Where("uuid = ? AND user_id = ?", s.Uuid, s.UserId).
+		Update("end_time", s.EndTime)
+}
+
+// sessionGetAll returns the most recent sessions for a user, newest first.
+func (s *Session) sessionGetAll() []Session {
+	var sessions []Session
+	db.Where("user_id = ?", s.UserId).Order("start_time desc").Limit(100).Find(&sessions)
+	return sessions
+}
+
+// sessionGetUUID returns the session matching Uuid for the given user.
+func (s *Session) sessionGetUUID() Session {
+	var session Session
+	db.Where("uuid = ? AND user_id = ?", s.Uuid, s.UserId).First(&session)
+	return session
+}
+
+// sessionCommandGet returns the commands belonging to a session, in the
+// order they were run.
+func (s *Session) sessionCommandGet() []Command {
+	var commands []Command
+	db.Where("session_id = ? AND user_id = ?", s.Uuid, s.UserId).Order("created asc").Find(&commands)
+	return commands
+}
+
+// sessionTotals computes TotalSessions, SessionStartTime and
+// SessionTotalCommands for a user's current session, replacing the old
+// process-id based approximation used by statusGet.
+func sessionTotals(userId uint, processId int) (total int, startTime int64, commandCount int) {
+	db.Model(&Session{}).Where("user_id = ?", userId).Count(&total)
+
+	var session Session
+	db.Where("user_id = ? AND process_id = ?", userId, processId).Order("start_time desc").First(&session)
+	startTime = session.StartTime
+
+	db.Model(&Command{}).Where("session_id = ? AND user_id = ?", session.Uuid, userId).Count(&commandCount)
+	return
+}
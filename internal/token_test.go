@@ -0,0 +1,93 @@
+/*
+ *
+ * Copyright © 2020 nicksherron <nsherron90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHasScope(t *testing.T) {
+	pat := &PersonalAccessToken{Scopes: "command:read, command:write"}
+
+	if !pat.hasScope(ScopeCommandRead) {
+		t.Error("expected command:read to be granted")
+	}
+	if pat.hasScope(ScopeCommandDelete) {
+		t.Error("expected command:delete to not be granted")
+	}
+}
+
+// newRequireScopeRouter builds a single-route gin engine guarded by
+// requireScope, with pat (or nil) pre-populated in the context the way
+// patAuthMiddleware would for a PAT-authenticated request.
+func newRequireScopeRouter(scope Scope, pat *PersonalAccessToken) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.DELETE("/command/:uuid", func(c *gin.Context) {
+		if pat != nil {
+			c.Set("pat", pat)
+		}
+		c.Next()
+	}, requireScope(scope), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequireScopeForbidsMissingScope(t *testing.T) {
+	pat := &PersonalAccessToken{Scopes: "command:read"}
+	r := newRequireScopeRouter(ScopeCommandDelete, pat)
+
+	req := httptest.NewRequest(http.MethodDelete, "/command/abc", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a PAT missing command:delete, got %d", rec.Code)
+	}
+}
+
+func TestRequireScopeAllowsGrantedScope(t *testing.T) {
+	pat := &PersonalAccessToken{Scopes: "command:read, command:delete"}
+	r := newRequireScopeRouter(ScopeCommandDelete, pat)
+
+	req := httptest.NewRequest(http.MethodDelete, "/command/abc", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a PAT granted command:delete, got %d", rec.Code)
+	}
+}
+
+func TestRequireScopeAllowsUnscopedJWTLogin(t *testing.T) {
+	r := newRequireScopeRouter(ScopeCommandDelete, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/command/abc", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a non-PAT (JWT) request, got %d", rec.Code)
+	}
+}